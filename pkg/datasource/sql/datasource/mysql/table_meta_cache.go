@@ -0,0 +1,334 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/seata/seata-go/pkg/common/log"
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+)
+
+// CheckerInterval is the period of each cache's background refresh loop,
+// read once when the cache is created. It carries the name of the
+// `client.tableMeta.checkerInterval` setting but is not loaded from
+// configuration in this package.
+var CheckerInterval = 60 * time.Second
+
+const tableUpdateTimeSql = "SELECT UPDATE_TIME, TABLE_ROWS FROM information_schema.tables WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?"
+const identitySql = "SELECT @@hostname, @@port, DATABASE()"
+
+// cacheMetrics counts cache outcomes so operators can tune CheckerInterval.
+type cacheMetrics struct {
+	hits     int64
+	misses   int64
+	refreshs int64
+}
+
+// MetricsSnapshot is a point-in-time read of a TableMetaCache's counters.
+type MetricsSnapshot struct {
+	Hits     int64
+	Misses   int64
+	Refreshs int64
+}
+
+type tableMetaCacheEntry struct {
+	meta        types.TableMeta
+	version     int64
+	updateTime  string
+	tableRows   string
+	lastRefresh time.Time
+}
+
+// TableMetaCache caches TableMeta per table name for one (host:port, dbName)
+// connection, refreshing stale entries in the background instead of
+// re-querying information_schema on every GetTableMeta call.
+type TableMetaCache struct {
+	schema  string
+	trigger *mysqlTrigger
+
+	cache sync.Map // table name -> *tableMetaCacheEntry
+	sf    singleflight.Group
+
+	metrics cacheMetrics
+
+	// dbMu/db hold a long-lived pool for the background refresh loop,
+	// registered via RegisterDB; nil until then, in which case a tick is a
+	// no-op rather than querying a borrowed, possibly-closed *sql.Conn.
+	dbMu sync.Mutex
+	db   *sql.DB
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+func newTableMetaCache(schema string) *TableMetaCache {
+	c := &TableMetaCache{
+		schema:  schema,
+		trigger: NewMysqlTrigger(),
+		stopCh:  make(chan struct{}),
+	}
+
+	go c.refreshLoop()
+
+	return c
+}
+
+// setDB registers the pool the background refresh loop uses.
+func (c *TableMetaCache) setDB(db *sql.DB) {
+	c.dbMu.Lock()
+	c.db = db
+	c.dbMu.Unlock()
+}
+
+// GetTableMeta returns the cached TableMeta for tableName, loading it via
+// the underlying mysqlTrigger on a cache miss. Concurrent misses for the
+// same table collapse into a single LoadOne call.
+func (c *TableMetaCache) GetTableMeta(ctx context.Context, tableName string, conn *sql.Conn) (types.TableMeta, error) {
+	if entry, ok := c.cache.Load(tableName); ok {
+		atomic.AddInt64(&c.metrics.hits, 1)
+		return entry.(*tableMetaCacheEntry).meta, nil
+	}
+
+	atomic.AddInt64(&c.metrics.misses, 1)
+
+	v, err, _ := c.sf.Do(tableName, func() (interface{}, error) {
+		meta, err := c.trigger.LoadOne(ctx, c.schema, tableName, conn)
+		if err != nil {
+			return types.TableMeta{}, err
+		}
+
+		updateTime, tableRows := c.snapshotState(ctx, conn, tableName)
+
+		entry := &tableMetaCacheEntry{
+			meta:        meta,
+			version:     1,
+			updateTime:  updateTime,
+			tableRows:   tableRows,
+			lastRefresh: time.Now(),
+		}
+		c.cache.Store(tableName, entry)
+
+		return meta, nil
+	})
+	if err != nil {
+		return types.TableMeta{}, err
+	}
+
+	return v.(types.TableMeta), nil
+}
+
+// snapshotState reads UPDATE_TIME/TABLE_ROWS as a baseline for the background loop to diff against.
+func (c *TableMetaCache) snapshotState(ctx context.Context, conn *sql.Conn, tableName string) (string, string) {
+	var updateTime, tableRows sql.NullString
+	row := conn.QueryRowContext(ctx, tableUpdateTimeSql, c.schema, tableName)
+	if err := row.Scan(&updateTime, &tableRows); err != nil {
+		return "", ""
+	}
+
+	return updateTime.String, tableRows.String
+}
+
+// Refresh forces a reload of tableName on the next GetTableMeta call.
+func (c *TableMetaCache) Refresh(tableName string) {
+	c.cache.Delete(tableName)
+}
+
+// Clear drops every cached entry.
+func (c *TableMetaCache) Clear() {
+	c.cache.Range(func(key, _ interface{}) bool {
+		c.cache.Delete(key)
+		return true
+	})
+}
+
+// Metrics returns a snapshot of the cache's hit/miss/refresh counters.
+func (c *TableMetaCache) Metrics() MetricsSnapshot {
+	return MetricsSnapshot{
+		Hits:     atomic.LoadInt64(&c.metrics.hits),
+		Misses:   atomic.LoadInt64(&c.metrics.misses),
+		Refreshs: atomic.LoadInt64(&c.metrics.refreshs),
+	}
+}
+
+// refreshLoop periodically invalidates entries whose UPDATE_TIME/TABLE_ROWS changed.
+func (c *TableMetaCache) refreshLoop() {
+	ticker := time.NewTicker(CheckerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkAndInvalidate()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// checkAndInvalidate pulls a fresh conn from the registered pool for one
+// pass; it's a no-op until RegisterDB has been called for this schema.
+func (c *TableMetaCache) checkAndInvalidate() {
+	c.dbMu.Lock()
+	db := c.db
+	c.dbMu.Unlock()
+
+	if db == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Errorf("[TableMetaCache] open conn for refresh fail, err: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	c.cache.Range(func(key, value interface{}) bool {
+		tableName := key.(string)
+		entry := value.(*tableMetaCacheEntry)
+
+		var updateTime, tableRows sql.NullString
+		row := conn.QueryRowContext(ctx, tableUpdateTimeSql, c.schema, tableName)
+		if err := row.Scan(&updateTime, &tableRows); err != nil {
+			log.Errorf("[TableMetaCache] check table meta fail, table: %s, err: %v", tableName, err)
+			return true
+		}
+
+		if updateTime.String != entry.updateTime || tableRows.String != entry.tableRows {
+			atomic.AddInt64(&c.metrics.refreshs, 1)
+			c.cache.Delete(tableName)
+		}
+
+		return true
+	})
+}
+
+// Stop terminates the background refresh goroutine.
+func (c *TableMetaCache) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+}
+
+var (
+	managerMu sync.Mutex
+	caches    = make(map[string]*TableMetaCache) // CacheKey(host, port, dbName) -> cache
+)
+
+// tableMetaInstance dispatches GetTableMeta to the TableMetaCache for the
+// current connection's (host, port, dbName).
+type tableMetaInstance struct{}
+
+// GetTableMetaInstance returns the process-wide entry point for table meta lookups.
+func GetTableMetaInstance() *tableMetaInstance {
+	return &tableMetaInstance{}
+}
+
+// GetTableMeta implements the call site used by the undo log manager:
+// mysql.GetTableMetaInstance().GetTableMeta(ctx, tableName, conn). A rollback
+// walks every SQLUndoLog row on the same conn, so the identity lookup is
+// cached per conn instead of re-querying @@hostname on every row.
+func (i *tableMetaInstance) GetTableMeta(ctx context.Context, tableName string, conn *sql.Conn) (types.TableMeta, error) {
+	host, port, dbName, err := cachedConnectionIdentity(ctx, conn)
+	if err != nil {
+		return types.TableMeta{}, err
+	}
+
+	return getOrCreateCache(CacheKey(host, port, dbName), dbName).GetTableMeta(ctx, tableName, conn)
+}
+
+// RegisterDB associates a long-lived pool with the cache for (host, port,
+// dbName), so the background refresh loop can pull its own connections
+// instead of reusing a caller-owned, short-lived *sql.Conn. Call this once
+// when the datasource proxy opens a pool for a schema.
+func RegisterDB(host, port, dbName string, db *sql.DB) {
+	getOrCreateCache(CacheKey(host, port, dbName), dbName).setDB(db)
+}
+
+// CacheKey is the cache key for a given (host, port, dbName); exported so
+// RegisterDB can be called ahead of any GetTableMeta call with a matching key.
+func CacheKey(host, port, dbName string) string {
+	return host + ":" + port + "/" + dbName
+}
+
+func getOrCreateCache(key, schema string) *TableMetaCache {
+	managerMu.Lock()
+	defer managerMu.Unlock()
+
+	c, ok := caches[key]
+	if !ok {
+		c = newTableMetaCache(schema)
+		caches[key] = c
+	}
+
+	return c
+}
+
+// connectionIdentity resolves the (host, port, dbName) conn is talking to,
+// so two clusters whose schemas share a name don't share a cache entry.
+func connectionIdentity(ctx context.Context, conn *sql.Conn) (host, port, dbName string, err error) {
+	if err = conn.QueryRowContext(ctx, identitySql).Scan(&host, &port, &dbName); err != nil {
+		return "", "", "", err
+	}
+
+	return host, port, dbName, nil
+}
+
+type connIdentity struct {
+	host, port, dbName string
+}
+
+// identityCache memoizes connectionIdentity per *sql.Conn so a rollback that
+// calls GetTableMeta once per SQLUndoLog row issues the @@hostname query
+// only once per conn, not once per row. Entries are evicted via a finalizer
+// on conn rather than on Close, since *sql.Conn exposes no close hook; keys
+// are the conn's address, so the map itself does not keep conn reachable.
+var identityCache sync.Map // uintptr(unsafe.Pointer(*sql.Conn)) -> connIdentity
+
+// cachedConnectionIdentity returns conn's (host, port, dbName), resolving
+// and caching it on the first call for this conn.
+func cachedConnectionIdentity(ctx context.Context, conn *sql.Conn) (host, port, dbName string, err error) {
+	key := uintptr(unsafe.Pointer(conn))
+	if v, ok := identityCache.Load(key); ok {
+		id := v.(connIdentity)
+		return id.host, id.port, id.dbName, nil
+	}
+
+	host, port, dbName, err = connectionIdentity(ctx, conn)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	identityCache.Store(key, connIdentity{host: host, port: port, dbName: dbName})
+	runtime.SetFinalizer(conn, func(c *sql.Conn) {
+		identityCache.Delete(uintptr(unsafe.Pointer(c)))
+	})
+
+	return host, port, dbName, nil
+}
@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+)
+
+func TestTableMetaCache_GetTableMeta_HitsAfterFirstLoad(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	mock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(
+		sqlmock.NewRows([]string{"TABLE_CATALOG", "TABLE_NAME", "TABLE_SCHEMA", "COLUMN_NAME", "DATA_TYPE", "COLUMN_TYPE", "COLUMN_KEY", "IS_NULLABLE", "EXTRA"}).
+			AddRow("def", "t_user", "db1", "id", "int", "int", "PRI", "NO", ""))
+	mock.ExpectPrepare("SELECT").ExpectQuery().WillReturnRows(
+		sqlmock.NewRows([]string{"INDEX_NAME", "COLUMN_NAME", "NON_UNIQUE", "INDEX_TYPE", "COLLATION", "CARDINALITY"}).
+			AddRow("PRIMARY", "id", "0", "BTREE", "A", 1))
+	mock.ExpectQuery("SELECT UPDATE_TIME, TABLE_ROWS").WillReturnRows(
+		sqlmock.NewRows([]string{"UPDATE_TIME", "TABLE_ROWS"}).AddRow("2026-01-01 00:00:00", "1"))
+
+	c := newTableMetaCache("db1")
+	defer c.Stop()
+
+	meta, err := c.GetTableMeta(context.Background(), "t_user", conn)
+	assert.NoError(t, err)
+	assert.Equal(t, "t_user", meta.Name)
+	assert.Equal(t, int64(0), c.Metrics().Hits)
+	assert.Equal(t, int64(1), c.Metrics().Misses)
+
+	meta, err = c.GetTableMeta(context.Background(), "t_user", conn)
+	assert.NoError(t, err)
+	assert.Equal(t, "t_user", meta.Name)
+	assert.Equal(t, int64(1), c.Metrics().Hits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTableMetaCache_RefreshAndClear(t *testing.T) {
+	c := newTableMetaCache("db1")
+	defer c.Stop()
+
+	c.cache.Store("t_user", &tableMetaCacheEntry{meta: types.TableMeta{Name: "t_user"}})
+	c.Refresh("t_user")
+	_, ok := c.cache.Load("t_user")
+	assert.False(t, ok)
+
+	c.cache.Store("t_user", &tableMetaCacheEntry{meta: types.TableMeta{Name: "t_user"}})
+	c.cache.Store("t_order", &tableMetaCacheEntry{meta: types.TableMeta{Name: "t_order"}})
+	c.Clear()
+	_, ok = c.cache.Load("t_user")
+	assert.False(t, ok)
+	_, ok = c.cache.Load("t_order")
+	assert.False(t, ok)
+}
+
+func TestCacheKey_DistinguishesSameSchemaDifferentHost(t *testing.T) {
+	assert.NotEqual(t, CacheKey("host-a", "3306", "db1"), CacheKey("host-b", "3306", "db1"))
+	assert.Equal(t, CacheKey("host-a", "3306", "db1"), CacheKey("host-a", "3306", "db1"))
+}
+
+func TestCachedConnectionIdentity_QueriesOnceForRepeatedCalls(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	mock.ExpectQuery("SELECT @@hostname, @@port, DATABASE()").WillReturnRows(
+		sqlmock.NewRows([]string{"host", "port", "db"}).AddRow("host-a", "3306", "db1"))
+
+	for i := 0; i < 3; i++ {
+		host, port, dbName, err := cachedConnectionIdentity(context.Background(), conn)
+		assert.NoError(t, err)
+		assert.Equal(t, "host-a", host)
+		assert.Equal(t, "3306", port)
+		assert.Equal(t, "db1", dbName)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTableMetaCache_CheckAndInvalidate_NoopWithoutRegisteredDB(t *testing.T) {
+	c := newTableMetaCache("db1")
+	defer c.Stop()
+
+	c.cache.Store("t_user", &tableMetaCacheEntry{meta: types.TableMeta{Name: "t_user"}, updateTime: "2026-01-01"})
+	c.checkAndInvalidate()
+
+	entry, ok := c.cache.Load("t_user")
+	assert.True(t, ok)
+	assert.Equal(t, "2026-01-01", entry.(*tableMetaCacheEntry).updateTime)
+}
+
+func TestTableMetaCache_CheckAndInvalidate_UsesRegisteredDB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	c := newTableMetaCache("db1")
+	defer c.Stop()
+	c.setDB(db)
+
+	c.cache.Store("t_user", &tableMetaCacheEntry{meta: types.TableMeta{Name: "t_user"}, updateTime: "2026-01-01", tableRows: "1"})
+
+	mock.ExpectQuery("SELECT UPDATE_TIME, TABLE_ROWS").WillReturnRows(
+		sqlmock.NewRows([]string{"UPDATE_TIME", "TABLE_ROWS"}).AddRow("2026-02-02 00:00:00", "2"))
+
+	c.checkAndInvalidate()
+	time.Sleep(10 * time.Millisecond)
+
+	_, ok := c.cache.Load("t_user")
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), c.Metrics().Refreshs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
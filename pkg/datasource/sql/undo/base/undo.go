@@ -21,13 +21,17 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
+
 	"github.com/seata/seata-go/pkg/datasource/sql/datasource/mysql"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/compressor"
 	"github.com/seata/seata-go/pkg/datasource/sql/undo/factor"
 	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/parser"
 
 	"github.com/pkg/errors"
 	"github.com/seata/seata-go/pkg/common/log"
@@ -38,6 +42,9 @@ import (
 	"github.com/seata/seata-go/pkg/datasource/sql/types"
 )
 
+// mySQLDuplicateEntryErrNo is the MySQL error number for a duplicate-key violation.
+const mySQLDuplicateEntryErrNo = 1062
+
 var _ undo.UndoLogManager = (*BaseUndoLogManager)(nil)
 
 var ErrorDeleteUndoLogParamsFault = errors.New("xid or branch_id can't nil")
@@ -61,11 +68,110 @@ func NewBaseUndoLogManager() *BaseUndoLogManager {
 func (m *BaseUndoLogManager) Init() {
 }
 
-// InsertUndoLog
+// InsertUndoLog encodes and writes every branch undo log in a single multi-row INSERT.
 func (m *BaseUndoLogManager) InsertUndoLog(l []impl.BranchUndoLog, tx driver.Tx) error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	execer, ok := tx.(driver.Execer)
+	if !ok {
+		return fmt.Errorf("undo: transaction does not support Exec")
+	}
+
+	p, err := parser.GetDefaultParser()
+	if err != nil {
+		log.Errorf("[InsertUndoLog] get default undo log parser fail, err: %v", err)
+		return err
+	}
+
+	var valuesSQL strings.Builder
+	args := make([]driver.Value, 0, len(l)*5)
+
+	for i, branchUndoLog := range l {
+		encoded, err := p.Encode(branchUndoLog)
+		if err != nil {
+			log.Errorf("[InsertUndoLog] encode undo log fail, err: %v", err)
+			return err
+		}
+
+		rollbackInfo, compressorName, err := m.compressIfNeeded(encoded)
+		if err != nil {
+			log.Errorf("[InsertUndoLog] compress undo log fail, err: %v", err)
+			return err
+		}
+
+		contextMap := map[string]string{constant.SerializerKey: p.GetName()}
+		if compressorName != "" {
+			contextMap[constant.CompressorTypeKey] = compressorName
+		}
+
+		if i > 0 {
+			valuesSQL.WriteString(",")
+		}
+		valuesSQL.WriteString("(?,?,?,?,?)")
+
+		args = append(args,
+			driver.Value(branchUndoLog.BranchID),
+			driver.Value(branchUndoLog.Xid),
+			driver.Value(m.EncodeMap(contextMap)),
+			driver.Value(rollbackInfo),
+			driver.Value(int64(constant.UndoLogStatusNormal)),
+		)
+	}
+
+	insertSQL := "INSERT INTO " + constant.UndoLogTableName +
+		"(branch_id,xid,context,rollback_info,log_status) VALUES" + valuesSQL.String()
+
+	if _, err = execer.Exec(insertSQL, args); err != nil {
+		log.Errorf("[InsertUndoLog] exec insert undo log fail, err: %v", err)
+		return err
+	}
+
 	return nil
 }
 
+// insertUndoLogWithGlobalFinished writes a GlobalFinished guard row so a
+// late branch rollback finds a row and replays nothing; a duplicate-key
+// error is treated as success.
+func (m *BaseUndoLogManager) insertUndoLogWithGlobalFinished(ctx context.Context, xid string, branchID int64, conn *sql.Conn) error {
+	p, err := parser.GetDefaultParser()
+	if err != nil {
+		log.Errorf("[insertUndoLogWithGlobalFinished] get default undo log parser fail, err: %v", err)
+		return err
+	}
+
+	contextMap := map[string]string{constant.SerializerKey: p.GetName()}
+
+	insertSQL := "INSERT INTO " + constant.UndoLogTableName +
+		"(branch_id,xid,context,rollback_info,log_status) VALUES(?,?,?,?,?)"
+
+	stmt, err := conn.PrepareContext(ctx, insertSQL)
+	if err != nil {
+		log.Errorf("[insertUndoLogWithGlobalFinished] prepare sql fail, err: %v", err)
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, branchID, xid, m.EncodeMap(contextMap), p.GetDefaultContent(), constant.UndoLogStatusGlobalFinished)
+	if err != nil {
+		if isDuplicateEntryErr(err) {
+			log.Infof("[insertUndoLogWithGlobalFinished] guard row already exists, xid: %s, branchID: %d", xid, branchID)
+			return nil
+		}
+		log.Errorf("[insertUndoLogWithGlobalFinished] exec insert fail, err: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// isDuplicateEntryErr reports whether err is a MySQL duplicate-key violation.
+func isDuplicateEntryErr(err error) bool {
+	mysqlErr, ok := err.(*mysqldriver.MySQLError)
+	return ok && mysqlErr.Number == mySQLDuplicateEntryErrNo
+}
+
 // DeleteUndoLog exec delete single undo log operate
 func (m *BaseUndoLogManager) DeleteUndoLog(ctx context.Context, xid string, branchID int64, conn *sql.Conn) error {
 	stmt, err := conn.PrepareContext(ctx, DeleteUndoLogSql)
@@ -194,16 +300,23 @@ func (m *BaseUndoLogManager) RunUndo(
 			return nil
 		}
 
-		// Todo pr 242 调用对应的 parser 方法
-		/*contextMap := m.parseContext(context)
-		rollbackInfo := m.getRollbackInfo(rollbackInfo, contextMap)
-		serializer := m.getSerializer(contextMap)
-		branchUndoLog = parser.decode(rollbackInfo);
-		*/
+		contextMap := m.parseContext(context)
+
+		p, err2 := parser.GetUndoLogParser(m.getSerializer(contextMap))
+		if err2 != nil {
+			err = err2
+			log.Errorf("[Undo] get undo log parser fail, err: %v", err)
+			return err
+		}
+
+		if rollbackInfo, err = m.getRollbackInfo(rollbackInfo, contextMap); err != nil {
+			log.Errorf("[Undo] decompress rollback info fail, err: %v", err)
+			return err
+		}
 
-		// Todo 替换成 parser 解析器解析
 		var branchUndoLog impl.BranchUndoLog
-		if err = json.Unmarshal(rollbackInfo, &branchUndoLog); err != nil {
+		if branchUndoLog, err = p.Decode(rollbackInfo); err != nil {
+			log.Errorf("[Undo] decode undo log fail, err: %v", err)
 			return err
 		}
 
@@ -251,11 +364,12 @@ func (m *BaseUndoLogManager) RunUndo(
 			log.Errorf("[Undo] delete undo log fail, err: %v", err)
 			return err
 		}
+	} else {
+		if err = m.insertUndoLogWithGlobalFinished(ctx, xid, branchID, conn); err != nil {
+			log.Errorf("[Undo] insert undo log with global finished fail, err: %v", err)
+			return err
+		}
 	}
-	// Todo 等 insertLog 合并后加上 insertUndoLogWithGlobalFinished 功能
-	/*else {
-
-	}*/
 
 	if err = tx.Commit(); err != nil {
 		log.Errorf("[Undo] execute on fail, err: %v", err)
@@ -343,16 +457,53 @@ func (m *BaseUndoLogManager) DecodeMap(str string) map[string]string {
 	return res
 }
 
-// getRollbackInfo
-func (m *BaseUndoLogManager) getRollbackInfo(rollbackInfo []byte, undoContext map[string]string) []byte {
-	// Todo 目前 insert undo log 未实现压缩功能，实现后补齐这块功能
-	// get compress type
-	/*compressorType, ok := undoContext[constant.CompressorTypeKey]
-	if ok {
+// EncodeMap encodes a context map into the "k=v&k=v" form DecodeMap parses.
+func (m *BaseUndoLogManager) EncodeMap(data map[string]string) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(data))
+	for k, v := range data {
+		pairs = append(pairs, k+KvSplit+v)
+	}
+
+	return strings.Join(pairs, PairSplit)
+}
+
+// getRollbackInfo decompresses rollbackInfo when undoContext carries a compressorType key.
+func (m *BaseUndoLogManager) getRollbackInfo(rollbackInfo []byte, undoContext map[string]string) ([]byte, error) {
+	compressorName, ok := undoContext[constant.CompressorTypeKey]
+	if !ok || compressorName == "" {
+		return rollbackInfo, nil
+	}
+
+	c, err := compressor.GetCompressorByName(compressorName)
+	if err != nil {
+		return nil, err
+	}
 
-	}*/
+	return c.Decompress(rollbackInfo)
+}
+
+// compressIfNeeded compresses data when enabled and over threshold, returning
+// the (possibly unmodified) bytes and the compressorType name to stamp, empty if unused.
+func (m *BaseUndoLogManager) compressIfNeeded(data []byte) ([]byte, string, error) {
+	if !compressor.CompressEnable || len(data) <= compressor.CompressThreshold {
+		return data, "", nil
+	}
+
+	c, err := compressor.GetCompressorByName(compressor.DefaultCompressorName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	compressed, err := c.Compress(data)
+	if err != nil {
+		return nil, "", err
+	}
 
-	return rollbackInfo
+	return compressed, c.GetType().Name(), nil
 }
 
 // getSerializer get serializer from undo context
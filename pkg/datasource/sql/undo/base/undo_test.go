@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+type fakeExecTx struct {
+	exec func(query string, args []driver.Value) (driver.Result, error)
+}
+
+func (f *fakeExecTx) Commit() error   { return nil }
+func (f *fakeExecTx) Rollback() error { return nil }
+
+func (f *fakeExecTx) Exec(query string, args []driver.Value) (driver.Result, error) {
+	return f.exec(query, args)
+}
+
+func TestInsertUndoLog_EmptyIsNoop(t *testing.T) {
+	m := NewBaseUndoLogManager()
+	assert.NoError(t, m.InsertUndoLog(nil, nil))
+}
+
+func TestInsertUndoLog_BuildsMultiRowInsert(t *testing.T) {
+	var gotQuery string
+	var gotArgs []driver.Value
+	tx := &fakeExecTx{exec: func(query string, args []driver.Value) (driver.Result, error) {
+		gotQuery = query
+		gotArgs = args
+		return driver.ResultNoRows, nil
+	}}
+
+	logs := []impl.BranchUndoLog{{Xid: "xid-1", BranchID: 1}, {Xid: "xid-2", BranchID: 2}}
+
+	m := NewBaseUndoLogManager()
+	err := m.InsertUndoLog(logs, tx)
+	assert.NoError(t, err)
+	assert.Contains(t, gotQuery, "VALUES(?,?,?,?,?),(?,?,?,?,?)")
+	assert.Len(t, gotArgs, 10)
+}
+
+func TestInsertUndoLogWithGlobalFinished_DuplicateKeyIsTolerated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	mock.ExpectPrepare("INSERT INTO").
+		ExpectExec().WillReturnError(&mysqldriver.MySQLError{Number: mySQLDuplicateEntryErrNo})
+
+	m := NewBaseUndoLogManager()
+	err = m.insertUndoLogWithGlobalFinished(context.Background(), "xid-1", 1, conn)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsDuplicateEntryErr(t *testing.T) {
+	assert.True(t, isDuplicateEntryErr(&mysqldriver.MySQLError{Number: mySQLDuplicateEntryErrNo}))
+	assert.False(t, isDuplicateEntryErr(&mysqldriver.MySQLError{Number: 1}))
+	assert.False(t, isDuplicateEntryErr(assert.AnError))
+}
+
+func TestEncodeDecodeMap_RoundTrip(t *testing.T) {
+	m := NewBaseUndoLogManager()
+	data := map[string]string{"serializer": "jackson"}
+	assert.Equal(t, data, m.DecodeMap(m.EncodeMap(data)))
+}
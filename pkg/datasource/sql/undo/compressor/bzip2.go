@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compressor
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/dsnet/compress/bzip2"
+)
+
+// bzip2Compressor uses dsnet/compress/bzip2 for both directions: the Go
+// standard library only ships a bzip2 reader, not a writer.
+type bzip2Compressor struct{}
+
+func init() {
+	RegisterCompressor(&bzip2Compressor{})
+}
+
+func (c *bzip2Compressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := bzip2.NewWriter(&buf, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *bzip2Compressor) Decompress(data []byte) ([]byte, error) {
+	r, err := bzip2.NewReader(bytes.NewReader(data), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+func (c *bzip2Compressor) GetType() CompressorType {
+	return CompressorTypeBZip2
+}
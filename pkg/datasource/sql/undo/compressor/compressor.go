@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package compressor provides the undo-log rollback_info compression
+// subsystem, keyed by the undo context's `compressorType` value.
+package compressor
+
+import "fmt"
+
+// CompressorType identifies a compression algorithm.
+type CompressorType int
+
+const (
+	CompressorTypeNone CompressorType = iota
+	CompressorTypeGzip
+	CompressorTypeZip
+	CompressorTypeLZ4
+	CompressorTypeBZip2
+	CompressorTypeDeflate
+	CompressorTypeZstd
+)
+
+var typeNames = map[CompressorType]string{
+	CompressorTypeNone:    "NONE",
+	CompressorTypeGzip:    "GZIP",
+	CompressorTypeZip:     "ZIP",
+	CompressorTypeLZ4:     "LZ4",
+	CompressorTypeBZip2:   "BZIP2",
+	CompressorTypeDeflate: "DEFLATE",
+	CompressorTypeZstd:    "ZSTD",
+}
+
+// Name returns the string stamped into the undo context's compressorType key.
+func (t CompressorType) Name() string {
+	return typeNames[t]
+}
+
+// Compressor shrinks rollback_info bytes before write and restores them before decode.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+	GetType() CompressorType
+}
+
+var registry = make(map[string]Compressor)
+
+// RegisterCompressor registers c under c.GetType().Name().
+func RegisterCompressor(c Compressor) {
+	registry[c.GetType().Name()] = c
+}
+
+// GetCompressorByName returns the compressor registered under name.
+func GetCompressorByName(name string) (Compressor, error) {
+	c, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("undo: no compressor registered for type: %s", name)
+	}
+
+	return c, nil
+}
+
+// CompressEnable, CompressThreshold and DefaultCompressorName carry the names
+// of the `client.undo.compress.enable`, `client.undo.compress.threshold` and
+// `client.undo.compress.type` settings; they are plain package variables and
+// are not loaded from configuration in this package.
+
+// CompressEnable gates whether InsertUndoLog compresses a serialized undo
+// log before writing it.
+var CompressEnable = false
+
+// CompressThreshold is the serialized payload size, in bytes, above which
+// compression kicks in.
+var CompressThreshold = 64 * 1024
+
+// DefaultCompressorName is the compressor used when CompressEnable is true.
+var DefaultCompressorName = CompressorTypeGzip.Name()
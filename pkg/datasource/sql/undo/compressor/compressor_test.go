@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compressor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompressorByName_RoundTripsAllRegisteredTypes(t *testing.T) {
+	data := []byte("hello undo log rollback_info")
+
+	for _, name := range []string{"NONE", "GZIP", "ZIP", "LZ4", "BZIP2", "DEFLATE", "ZSTD"} {
+		c, err := GetCompressorByName(name)
+		assert.NoError(t, err, name)
+
+		compressed, err := c.Compress(data)
+		assert.NoError(t, err, name)
+
+		decompressed, err := c.Decompress(compressed)
+		assert.NoError(t, err, name)
+		assert.Equal(t, data, decompressed, name)
+	}
+}
+
+func TestGetCompressorByName_UnknownNameErrors(t *testing.T) {
+	_, err := GetCompressorByName("unknown")
+	assert.Error(t, err)
+}
+
+func TestCompressorType_Name(t *testing.T) {
+	assert.Equal(t, "GZIP", CompressorTypeGzip.Name())
+	assert.Equal(t, "NONE", CompressorTypeNone.Name())
+}
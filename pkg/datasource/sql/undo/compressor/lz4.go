@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compressor
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+type lz4Compressor struct{}
+
+func init() {
+	RegisterCompressor(&lz4Compressor{})
+}
+
+func (c *lz4Compressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := lz4.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *lz4Compressor) Decompress(data []byte) ([]byte, error) {
+	r := lz4.NewReader(bytes.NewReader(data))
+	return io.ReadAll(r)
+}
+
+func (c *lz4Compressor) GetType() CompressorType {
+	return CompressorTypeLZ4
+}
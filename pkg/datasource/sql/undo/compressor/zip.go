@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compressor
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// zipEntryName is the single in-memory archive entry written for a
+// compressed undo log; it doesn't need to be unique across rows, only
+// present.
+const zipEntryName = "undo_log"
+
+type zipCompressor struct{}
+
+func init() {
+	RegisterCompressor(&zipCompressor{})
+}
+
+func (c *zipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+	entry, err := w.Create(zipEntryName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = entry.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *zipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if len(r.File) == 0 {
+		return nil, fmt.Errorf("undo: zip archive has no entries")
+	}
+
+	f, err := r.File[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+func (c *zipCompressor) GetType() CompressorType {
+	return CompressorTypeZip
+}
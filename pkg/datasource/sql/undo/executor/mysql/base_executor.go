@@ -0,0 +1,246 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mysql builds and runs the rollback statement for a MySQL
+// SQLUndoLog.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/seata/seata-go/pkg/common/log"
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+// OnlyCareUpdateColumns relaxes compareColumns for columns missing from the
+// current row. It carries the name of the `client.undo.onlyCareUpdateColumns`
+// setting but is not loaded from configuration in this package.
+var OnlyCareUpdateColumns = true
+
+// SQLUndoDirtyException means the current row no longer matches the captured image.
+type SQLUndoDirtyException struct {
+	TableName string
+	Reason    string
+}
+
+func (e *SQLUndoDirtyException) Error() string {
+	return fmt.Sprintf("undo: dirty data detected in table %s: %s", e.TableName, e.Reason)
+}
+
+// baseExecutor holds the helpers shared by the insert/update/delete undo executors.
+type baseExecutor struct {
+	sqlUndoLog impl.SQLUndoLog
+}
+
+// primaryKeyColumns resolves the primary-key column names from TableMeta.
+func (e *baseExecutor) primaryKeyColumns() ([]string, error) {
+	tableMeta := e.sqlUndoLog.GetTableMeta()
+
+	for _, index := range tableMeta.Indexs {
+		if index.IndexType == types.IndexPrimary {
+			names := make([]string, 0, len(index.Values))
+			for _, col := range index.Values {
+				names = append(names, col.ColumnName)
+			}
+			return names, nil
+		}
+	}
+
+	return nil, fmt.Errorf("undo: table %s has no primary key", tableMeta.Name)
+}
+
+// dataValidation diffs the current row against expectedImage; used by insert/update rollback.
+func (e *baseExecutor) dataValidation(ctx context.Context, conn *sql.Conn, expectedImage *types.RecordImage) error {
+	if expectedImage == nil || len(expectedImage.Rows) == 0 {
+		return nil
+	}
+
+	pkColumns, err := e.primaryKeyColumns()
+	if err != nil {
+		return err
+	}
+
+	tableName := e.sqlUndoLog.TableName
+
+	for _, row := range expectedImage.Rows {
+		expected := columnImagesToMap(row.Columns)
+
+		current, err := e.selectRowByPK(ctx, conn, tableName, pkColumns, expected)
+		if err != nil {
+			return err
+		}
+
+		if current == nil {
+			return &SQLUndoDirtyException{TableName: tableName, Reason: "row no longer exists"}
+		}
+
+		if err := compareColumns(tableName, expected, current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareColumns compares expected against the row currently in the
+// database. A column absent from current is tolerated when
+// OnlyCareUpdateColumns is set, since rollback only cares whether the
+// columns it is about to restore still hold the values it captured; a
+// present column whose value differs is always dirty.
+func compareColumns(tableName string, expected, current map[string]interface{}) error {
+	for name, expectedValue := range expected {
+		currentValue, ok := current[name]
+		if !ok {
+			if OnlyCareUpdateColumns {
+				continue
+			}
+			return &SQLUndoDirtyException{TableName: tableName, Reason: fmt.Sprintf("column %s missing from current row", name)}
+		}
+
+		if !valuesEqual(currentValue, expectedValue) {
+			return &SQLUndoDirtyException{
+				TableName: tableName,
+				Reason:    fmt.Sprintf("column %s expected %v but found %v", name, expectedValue, currentValue),
+			}
+		}
+	}
+
+	return nil
+}
+
+// valuesEqual compares a value scanned from the database against a captured
+// row image value. MySQL driver scans of VARCHAR/CHAR/TEXT columns into
+// *interface{} come back as []byte, while the captured image holds a Go
+// string for the same column, so both sides are normalized to string before
+// falling back to a formatted comparison for every other type.
+func valuesEqual(current, expected interface{}) bool {
+	if b, ok := current.([]byte); ok {
+		current = string(b)
+	}
+	if b, ok := expected.([]byte); ok {
+		expected = string(b)
+	}
+
+	return fmt.Sprint(current) == fmt.Sprint(expected)
+}
+
+// dataValidationForDelete confirms the row a rolled-back DELETE targeted is still absent;
+// beforeImage only supplies the primary-key values for the lookup. A row existing is the dirty case.
+func (e *baseExecutor) dataValidationForDelete(ctx context.Context, conn *sql.Conn, beforeImage *types.RecordImage) error {
+	if beforeImage == nil || len(beforeImage.Rows) == 0 {
+		return nil
+	}
+
+	pkColumns, err := e.primaryKeyColumns()
+	if err != nil {
+		return err
+	}
+
+	tableName := e.sqlUndoLog.TableName
+
+	for _, row := range beforeImage.Rows {
+		current, err := e.selectRowByPK(ctx, conn, tableName, pkColumns, columnImagesToMap(row.Columns))
+		if err != nil {
+			return err
+		}
+
+		if current != nil {
+			return &SQLUndoDirtyException{TableName: tableName, Reason: "row exists but should have been deleted"}
+		}
+	}
+
+	return nil
+}
+
+// selectRowByPK re-reads the current value of every column in row, returning nil when no row matches.
+func (e *baseExecutor) selectRowByPK(ctx context.Context, conn *sql.Conn, tableName string, pkColumns []string, row map[string]interface{}) (map[string]interface{}, error) {
+	columns := make([]string, 0, len(row))
+	for name := range row {
+		columns = append(columns, name)
+	}
+
+	whereClause, args, err := buildPKWhere(pkColumns, row)
+	if err != nil {
+		return nil, err
+	}
+
+	querySQL := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(columns, ","), tableName, whereClause)
+
+	stmt, err := conn.PrepareContext(ctx, querySQL)
+	if err != nil {
+		log.Errorf("[dataValidation] prepare sql fail, err: %v", err)
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		log.Errorf("[dataValidation] query sql fail, err: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err = rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(columns))
+	for i, name := range columns {
+		result[name] = values[i]
+	}
+
+	return result, nil
+}
+
+// columnImagesToMap flattens a RowImage's columns into a name->value map.
+func columnImagesToMap(columns []types.ColumnImage) map[string]interface{} {
+	result := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		result[col.ColumnName] = col.Value
+	}
+	return result
+}
+
+// buildPKWhere builds a "pk1=? AND pk2=?" clause and its bind args from row.
+func buildPKWhere(pkColumns []string, row map[string]interface{}) (string, []interface{}, error) {
+	conditions := make([]string, 0, len(pkColumns))
+	args := make([]interface{}, 0, len(pkColumns))
+
+	for _, pk := range pkColumns {
+		value, ok := row[pk]
+		if !ok {
+			return "", nil, fmt.Errorf("undo: missing primary key column %s in image", pk)
+		}
+		conditions = append(conditions, pk+"=?")
+		args = append(args, value)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
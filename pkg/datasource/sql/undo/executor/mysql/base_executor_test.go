@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+func newTestUndoLog(t *testing.T) (impl.SQLUndoLog, *sql.Conn, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+
+	tableMeta := types.TableMeta{
+		Name: "t_user",
+		Indexs: map[string]types.IndexMeta{
+			"PRIMARY": {
+				IndexType: types.IndexPrimary,
+				Values:    []types.ColumnMeta{{ColumnName: "id"}},
+			},
+		},
+	}
+
+	sqlUndoLog := impl.SQLUndoLog{TableName: "t_user"}
+	sqlUndoLog.SetTableMeta(tableMeta)
+
+	return sqlUndoLog, conn, mock, func() {
+		conn.Close()
+		db.Close()
+	}
+}
+
+func TestDataValidationForDelete_RowAbsent_Succeeds(t *testing.T) {
+	sqlUndoLog, conn, mock, cleanup := newTestUndoLog(t)
+	defer cleanup()
+
+	mock.ExpectPrepare("SELECT id FROM t_user").
+		ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	e := &baseExecutor{sqlUndoLog: sqlUndoLog}
+	before := &types.RecordImage{Rows: []types.RowImage{
+		{Columns: []types.ColumnImage{{ColumnName: "id", Value: int64(1)}}},
+	}}
+
+	assert.NoError(t, e.dataValidationForDelete(context.Background(), conn, before))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataValidationForDelete_RowPresent_IsDirty(t *testing.T) {
+	sqlUndoLog, conn, mock, cleanup := newTestUndoLog(t)
+	defer cleanup()
+
+	mock.ExpectPrepare("SELECT id FROM t_user").
+		ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	e := &baseExecutor{sqlUndoLog: sqlUndoLog}
+	before := &types.RecordImage{Rows: []types.RowImage{
+		{Columns: []types.ColumnImage{{ColumnName: "id", Value: int64(1)}}},
+	}}
+
+	err := e.dataValidationForDelete(context.Background(), conn, before)
+	assert.Error(t, err)
+	_, ok := err.(*SQLUndoDirtyException)
+	assert.True(t, ok)
+}
+
+func TestDataValidation_RowVanished_IsAlwaysDirty(t *testing.T) {
+	for _, onlyCareUpdateColumns := range []bool{true, false} {
+		OnlyCareUpdateColumns = onlyCareUpdateColumns
+
+		sqlUndoLog, conn, mock, cleanup := newTestUndoLog(t)
+
+		mock.ExpectPrepare("SELECT id FROM t_user").
+			ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		e := &baseExecutor{sqlUndoLog: sqlUndoLog}
+		after := &types.RecordImage{Rows: []types.RowImage{
+			{Columns: []types.ColumnImage{{ColumnName: "id", Value: int64(1)}}},
+		}}
+
+		err := e.dataValidation(context.Background(), conn, after)
+		assert.Error(t, err)
+		_, ok := err.(*SQLUndoDirtyException)
+		assert.True(t, ok)
+
+		cleanup()
+	}
+
+	OnlyCareUpdateColumns = true
+}
+
+func TestCompareColumns_StringColumnScannedAsBytes(t *testing.T) {
+	expected := map[string]interface{}{"name": "abc"}
+	current := map[string]interface{}{"name": []byte("abc")}
+
+	assert.NoError(t, compareColumns("t_user", expected, current))
+}
+
+func TestCompareColumns_ValueMismatchIsDirty(t *testing.T) {
+	expected := map[string]interface{}{"name": "abc"}
+	current := map[string]interface{}{"name": []byte("xyz")}
+
+	err := compareColumns("t_user", expected, current)
+	assert.Error(t, err)
+}
+
+func TestCompareColumns_OnlyCareUpdateColumns_TogglesMissingColumnTolerance(t *testing.T) {
+	expected := map[string]interface{}{"extra": "abc"}
+	current := map[string]interface{}{}
+
+	OnlyCareUpdateColumns = true
+	assert.NoError(t, compareColumns("t_user", expected, current))
+
+	OnlyCareUpdateColumns = false
+	assert.Error(t, compareColumns("t_user", expected, current))
+
+	OnlyCareUpdateColumns = true
+}
+
+func TestBuildPKWhere(t *testing.T) {
+	clause, args, err := buildPKWhere([]string{"id", "tenant_id"}, map[string]interface{}{"id": 1, "tenant_id": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "id=? AND tenant_id=?", clause)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	_, _, err = buildPKWhere([]string{"missing"}, map[string]interface{}{"id": 1})
+	assert.Error(t, err)
+}
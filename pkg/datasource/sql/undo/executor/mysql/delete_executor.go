@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/seata/seata-go/pkg/common/log"
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+// deleteExecutor rolls back a branch DELETE by re-inserting the before image.
+type deleteExecutor struct {
+	baseExecutor
+}
+
+// NewDeleteExecutor builds the executor that rolls back a branch DELETE.
+func NewDeleteExecutor(sqlUndoLog impl.SQLUndoLog) *deleteExecutor {
+	return &deleteExecutor{baseExecutor{sqlUndoLog: sqlUndoLog}}
+}
+
+// ExecuteOn validates the row is still absent, then re-inserts it from the before image.
+func (e *deleteExecutor) ExecuteOn(ctx context.Context, dbType types.DBType, undoLog impl.SQLUndoLog, conn *sql.Conn) error {
+	e.sqlUndoLog = undoLog
+	tableMeta := undoLog.GetTableMeta()
+
+	if err := e.dataValidationForDelete(ctx, conn, undoLog.BeforeImage); err != nil {
+		return err
+	}
+
+	for _, row := range undoLog.BeforeImage.Rows {
+		columns := make([]string, 0, len(row.Columns))
+		placeholders := make([]string, 0, len(row.Columns))
+		args := make([]interface{}, 0, len(row.Columns))
+
+		for _, col := range row.Columns {
+			colMeta, ok := tableMeta.Columns[col.ColumnName]
+			if ok && colMeta.Autoincrement && col.Value == nil {
+				continue
+			}
+
+			columns = append(columns, col.ColumnName)
+			placeholders = append(placeholders, "?")
+			args = append(args, col.Value)
+		}
+
+		insertSQL := fmt.Sprintf("INSERT INTO %s(%s) VALUES(%s)",
+			undoLog.TableName, strings.Join(columns, ","), strings.Join(placeholders, ","))
+
+		stmt, err := conn.PrepareContext(ctx, insertSQL)
+		if err != nil {
+			log.Errorf("[deleteExecutor] prepare sql fail, err: %v", err)
+			return err
+		}
+
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			stmt.Close()
+			log.Errorf("[deleteExecutor] exec insert fail, err: %v", err)
+			return err
+		}
+
+		if err = stmt.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
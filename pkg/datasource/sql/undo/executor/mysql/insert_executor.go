@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/seata/seata-go/pkg/common/log"
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+// insertExecutor rolls back a branch INSERT by deleting the row it added,
+// identified by the after image's primary-key columns.
+type insertExecutor struct {
+	baseExecutor
+}
+
+// NewInsertExecutor builds the executor that rolls back a branch INSERT.
+func NewInsertExecutor(sqlUndoLog impl.SQLUndoLog) *insertExecutor {
+	return &insertExecutor{baseExecutor{sqlUndoLog: sqlUndoLog}}
+}
+
+// ExecuteOn validates the row is still the one the branch inserted, then
+// deletes it by primary key.
+func (e *insertExecutor) ExecuteOn(ctx context.Context, dbType types.DBType, undoLog impl.SQLUndoLog, conn *sql.Conn) error {
+	e.sqlUndoLog = undoLog
+
+	if err := e.dataValidation(ctx, conn, undoLog.AfterImage); err != nil {
+		return err
+	}
+
+	pkColumns, err := e.primaryKeyColumns()
+	if err != nil {
+		return err
+	}
+
+	tableName := e.sqlUndoLog.TableName
+
+	for _, row := range undoLog.AfterImage.Rows {
+		whereClause, args, err := buildPKWhere(pkColumns, columnImagesToMap(row.Columns))
+		if err != nil {
+			return err
+		}
+
+		deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE %s", tableName, whereClause)
+
+		stmt, err := conn.PrepareContext(ctx, deleteSQL)
+		if err != nil {
+			log.Errorf("[insertExecutor] prepare sql fail, err: %v", err)
+			return err
+		}
+
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			stmt.Close()
+			log.Errorf("[insertExecutor] exec delete fail, err: %v", err)
+			return err
+		}
+
+		if err = stmt.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
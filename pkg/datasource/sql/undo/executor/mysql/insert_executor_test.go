@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+func TestInsertExecutor_ExecuteOn_DeletesInsertedRow(t *testing.T) {
+	sqlUndoLog, conn, mock, cleanup := newTestUndoLog(t)
+	defer cleanup()
+
+	sqlUndoLog.AfterImage = &types.RecordImage{Rows: []types.RowImage{
+		{Columns: []types.ColumnImage{{ColumnName: "id", Value: int64(1)}}},
+	}}
+
+	mock.ExpectPrepare("SELECT id FROM t_user").
+		ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectPrepare("DELETE FROM t_user").
+		ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	e := NewInsertExecutor(sqlUndoLog)
+	err := e.ExecuteOn(context.Background(), types.DBTypeMySQL, sqlUndoLog, conn)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/seata/seata-go/pkg/common/log"
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+// updateExecutor rolls back a branch UPDATE by restoring the before-image
+// values, matching rows by the before-image's primary-key columns.
+type updateExecutor struct {
+	baseExecutor
+}
+
+// NewUpdateExecutor builds the executor that rolls back a branch UPDATE.
+func NewUpdateExecutor(sqlUndoLog impl.SQLUndoLog) *updateExecutor {
+	return &updateExecutor{baseExecutor{sqlUndoLog: sqlUndoLog}}
+}
+
+// ExecuteOn validates the row still holds the after-image values, then
+// writes the before-image values back.
+func (e *updateExecutor) ExecuteOn(ctx context.Context, dbType types.DBType, undoLog impl.SQLUndoLog, conn *sql.Conn) error {
+	e.sqlUndoLog = undoLog
+
+	if err := e.dataValidation(ctx, conn, undoLog.AfterImage); err != nil {
+		return err
+	}
+
+	pkColumns, err := e.primaryKeyColumns()
+	if err != nil {
+		return err
+	}
+
+	pkSet := make(map[string]struct{}, len(pkColumns))
+	for _, pk := range pkColumns {
+		pkSet[pk] = struct{}{}
+	}
+
+	for _, row := range undoLog.BeforeImage.Rows {
+		rowValues := columnImagesToMap(row.Columns)
+
+		setClauses := make([]string, 0, len(row.Columns))
+		args := make([]interface{}, 0, len(row.Columns))
+		for _, col := range row.Columns {
+			if _, isPK := pkSet[col.ColumnName]; isPK {
+				continue
+			}
+			setClauses = append(setClauses, col.ColumnName+"=?")
+			args = append(args, col.Value)
+		}
+
+		whereClause, whereArgs, err := buildPKWhere(pkColumns, rowValues)
+		if err != nil {
+			return err
+		}
+		args = append(args, whereArgs...)
+
+		updateSQL := fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+			undoLog.TableName, strings.Join(setClauses, ","), whereClause)
+
+		stmt, err := conn.PrepareContext(ctx, updateSQL)
+		if err != nil {
+			log.Errorf("[updateExecutor] prepare sql fail, err: %v", err)
+			return err
+		}
+
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			stmt.Close()
+			log.Errorf("[updateExecutor] exec update fail, err: %v", err)
+			return err
+		}
+
+		if err = stmt.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
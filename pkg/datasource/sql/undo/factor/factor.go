@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package factor resolves the UndoExecutor that can roll back a given
+// SQLUndoLog, dispatching on the target database type and the original
+// SQLType captured when the undo log was recorded.
+package factor
+
+import (
+	"fmt"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/types"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/executor"
+	mysqlexecutor "github.com/seata/seata-go/pkg/datasource/sql/undo/executor/mysql"
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+type executorFactory func(sqlUndoLog impl.SQLUndoLog) executor.UndoExecutor
+
+var mysqlExecutors = map[impl.SQLType]executorFactory{
+	impl.SQLTypeInsert: func(l impl.SQLUndoLog) executor.UndoExecutor { return mysqlexecutor.NewInsertExecutor(l) },
+	impl.SQLTypeUpdate: func(l impl.SQLUndoLog) executor.UndoExecutor { return mysqlexecutor.NewUpdateExecutor(l) },
+	impl.SQLTypeDelete: func(l impl.SQLUndoLog) executor.UndoExecutor { return mysqlexecutor.NewDeleteExecutor(l) },
+}
+
+// GetUndoExecutor resolves the UndoExecutor that can roll back sqlUndoLog
+// for dbType.
+func GetUndoExecutor(dbType types.DBType, sqlUndoLog impl.SQLUndoLog) (executor.UndoExecutor, error) {
+	switch dbType {
+	case types.DBTypeMySQL:
+		factory, ok := mysqlExecutors[sqlUndoLog.SQLType]
+		if !ok {
+			return nil, fmt.Errorf("undo: unsupported sql type for mysql undo executor: %v", sqlUndoLog.SQLType)
+		}
+		return factory(sqlUndoLog), nil
+	default:
+		return nil, fmt.Errorf("undo: unsupported db type for undo executor: %v", dbType)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+const fastjsonParserName = "fastjson"
+
+// FastjsonParser serializes BranchUndoLog the way Alibaba fastjson would;
+// reuses encoding/json since the wire format is identical.
+type FastjsonParser struct{}
+
+func init() {
+	RegisterUndoLogParser(&FastjsonParser{})
+}
+
+// Encode implements UndoLogParser.
+func (p *FastjsonParser) Encode(branchUndoLog impl.BranchUndoLog) ([]byte, error) {
+	return json.Marshal(branchUndoLog)
+}
+
+// Decode implements UndoLogParser.
+func (p *FastjsonParser) Decode(data []byte) (impl.BranchUndoLog, error) {
+	var branchUndoLog impl.BranchUndoLog
+	if err := json.Unmarshal(data, &branchUndoLog); err != nil {
+		return impl.BranchUndoLog{}, err
+	}
+
+	return branchUndoLog, nil
+}
+
+// GetName implements UndoLogParser.
+func (p *FastjsonParser) GetName() string {
+	return fastjsonParserName
+}
+
+// GetDefaultContent implements UndoLogParser.
+func (p *FastjsonParser) GetDefaultContent() []byte {
+	return defaultContent
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package parser provides the pluggable undo-log serializer registry keyed
+// by the undo_log `context` column's `serializer` value.
+package parser
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+// UndoLogParser encodes and decodes a BranchUndoLog to and from the bytes
+// persisted in the undo_log `rollback_info` column.
+type UndoLogParser interface {
+	// Encode serializes a BranchUndoLog into rollback_info bytes.
+	Encode(branchUndoLog impl.BranchUndoLog) ([]byte, error)
+	// Decode deserializes rollback_info bytes back into a BranchUndoLog.
+	Decode(data []byte) (impl.BranchUndoLog, error)
+	// GetName returns the serializer name stamped into the undo context, e.g.
+	// "jackson", "fastjson", "protobuf"; an alias such as "json" is not returned.
+	GetName() string
+	// GetDefaultContent returns the rollback_info placeholder for a GlobalFinished guard row.
+	GetDefaultContent() []byte
+}
+
+// DefaultParserName is used to encode new undo logs and to resolve rows with
+// no `serializer` key at all. It carries the name of the
+// `client.undo.logSerialization` setting but is not loaded from
+// configuration in this package; SetDefaultParserName changes it.
+var DefaultParserName = jacksonParserName
+
+// SetDefaultParserName overrides DefaultParserName.
+func SetDefaultParserName(name string) {
+	DefaultParserName = name
+}
+
+var (
+	mu      sync.RWMutex
+	parsers = make(map[string]UndoLogParser)
+)
+
+// RegisterUndoLogParser registers p under p.GetName().
+func RegisterUndoLogParser(p UndoLogParser) {
+	RegisterUndoLogParserAs(p.GetName(), p)
+}
+
+// RegisterUndoLogParserAs registers p under an explicit name, letting one
+// implementation serve several serializer names (e.g. "jackson" and "json").
+func RegisterUndoLogParserAs(name string, p UndoLogParser) {
+	mu.Lock()
+	defer mu.Unlock()
+	parsers[name] = p
+}
+
+// GetUndoLogParser returns the parser registered under name, falling back to
+// DefaultParserName when name is empty.
+func GetUndoLogParser(name string) (UndoLogParser, error) {
+	if name == "" {
+		name = DefaultParserName
+	}
+
+	mu.RLock()
+	p, ok := parsers[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("undo: no parser registered for serializer: %s", name)
+	}
+
+	return p, nil
+}
+
+// GetDefaultParser returns the parser used to encode newly written undo logs.
+func GetDefaultParser() (UndoLogParser, error) {
+	return GetUndoLogParser(DefaultParserName)
+}
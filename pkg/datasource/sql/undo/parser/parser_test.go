@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+func TestGetUndoLogParser_JacksonAndJsonAliasRoundTrip(t *testing.T) {
+	branchUndoLog := impl.BranchUndoLog{Xid: "xid-1", BranchID: 1}
+
+	for _, name := range []string{"jackson", "json"} {
+		p, err := GetUndoLogParser(name)
+		assert.NoError(t, err)
+
+		data, err := p.Encode(branchUndoLog)
+		assert.NoError(t, err)
+
+		decoded, err := p.Decode(data)
+		assert.NoError(t, err)
+		assert.Equal(t, branchUndoLog.Xid, decoded.Xid)
+		assert.Equal(t, branchUndoLog.BranchID, decoded.BranchID)
+	}
+}
+
+func TestGetUndoLogParser_FastjsonRoundTrip(t *testing.T) {
+	branchUndoLog := impl.BranchUndoLog{Xid: "xid-2", BranchID: 2}
+
+	p, err := GetUndoLogParser("fastjson")
+	assert.NoError(t, err)
+
+	data, err := p.Encode(branchUndoLog)
+	assert.NoError(t, err)
+
+	decoded, err := p.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, branchUndoLog.Xid, decoded.Xid)
+}
+
+func TestGetUndoLogParser_EmptyNameFallsBackToDefault(t *testing.T) {
+	p, err := GetUndoLogParser("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultParserName, p.GetName())
+}
+
+func TestGetUndoLogParser_UnregisteredNameErrors(t *testing.T) {
+	_, err := GetUndoLogParser("unknown-serializer")
+	assert.Error(t, err)
+}
+
+func TestGetUndoLogParser_Protobuf_ReportsNotImplemented(t *testing.T) {
+	p, err := GetUndoLogParser("protobuf")
+	assert.NoError(t, err)
+
+	_, err = p.Encode(impl.BranchUndoLog{})
+	assert.ErrorIs(t, err, ErrProtobufNotImplemented)
+
+	_, err = p.Decode([]byte("{}"))
+	assert.ErrorIs(t, err, ErrProtobufNotImplemented)
+}
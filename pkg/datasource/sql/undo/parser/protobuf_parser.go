@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"errors"
+
+	"github.com/seata/seata-go/pkg/datasource/sql/undo/impl"
+)
+
+const protobufParserName = "protobuf"
+
+// ErrProtobufNotImplemented is returned for every protobuf-serialized row
+// until BranchUndoLog has generated Go types from Seata-Java's .proto
+// schema. ProtobufParser is registered under "protobuf" anyway so a branch
+// written with that serializer configured fails with this specific error
+// instead of the generic "no parser registered" one.
+var ErrProtobufNotImplemented = errors.New("undo: protobuf serializer is not implemented yet")
+
+// ProtobufParser is a named stub for the "protobuf" serializer; every method errors.
+type ProtobufParser struct{}
+
+func init() {
+	RegisterUndoLogParser(&ProtobufParser{})
+}
+
+// Encode implements UndoLogParser.
+func (p *ProtobufParser) Encode(branchUndoLog impl.BranchUndoLog) ([]byte, error) {
+	return nil, ErrProtobufNotImplemented
+}
+
+// Decode implements UndoLogParser.
+func (p *ProtobufParser) Decode(data []byte) (impl.BranchUndoLog, error) {
+	return impl.BranchUndoLog{}, ErrProtobufNotImplemented
+}
+
+// GetName implements UndoLogParser.
+func (p *ProtobufParser) GetName() string {
+	return protobufParserName
+}
+
+// GetDefaultContent implements UndoLogParser.
+func (p *ProtobufParser) GetDefaultContent() []byte {
+	return defaultContent
+}